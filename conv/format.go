@@ -0,0 +1,205 @@
+// Copyright (c) 2022 RFull Development
+// This source code is managed under the MIT license. See LICENSE in the project root.
+package conv
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+)
+
+// formatEncoderは出力フォーマット毎のキー正規化とシリアライズを担います。
+// 新しいフォーマットを追加する場合は本インターフェースの実装を1つ登録するだけで済みます。
+type formatEncoder interface {
+	// normalizeKeyはHTTPヘッダのキーをこのフォーマットにおけるキー名へ変換します。
+	normalizeKey(key string) (string, error)
+	// encodeはdata(map[string]interface{}、または複数メッセージ時は[]interface{})を
+	// このフォーマットの文字列表現へシリアライズします。
+	encode(data interface{}) (string, error)
+}
+
+// formatEncodersは出力フォーマットと対応するformatEncoderのレジストリです。
+var formatEncoders = map[Format]formatEncoder{
+	JsonFormat: jsonEncoder{},
+	YamlFormat: yamlEncoder{},
+	TomlFormat: tomlEncoder{},
+	XmlFormat:  xmlEncoder{},
+}
+
+// encoderForはconvの出力フォーマットに対応するformatEncoderを返却します。
+func (conv *HttpHeaderConverter) encoderFor() (formatEncoder, error) {
+	e, ok := formatEncoders[conv.outputFormat]
+	if !ok {
+		return nil, fmt.Errorf("unknown output format: %s", conv.outputFormat)
+	}
+	return e, nil
+}
+
+// jsonEncoderはJSON形式のformatEncoderです。
+type jsonEncoder struct{}
+
+// normalizeKeyはJSONキー名を返却します。
+// JSONキー名はHTTPヘッダのキーより不要な文字を除去し、キャメルケースに変換した結果です。
+func (jsonEncoder) normalizeKey(key string) (string, error) {
+	t := keyReplacer.Replace(key)
+	c := cases.Title(language.Und)
+	t = c.String(t)
+	tl := strings.Split(t, " ")
+	tl[0] = strings.ToLower(tl[0])
+	t = strings.Join(tl, "")
+	return t, nil
+}
+
+func (jsonEncoder) encode(data interface{}) (string, error) {
+	t, e := json.Marshal(data)
+	if e != nil {
+		return "", fmt.Errorf("cannot encode JSON: %w", e)
+	}
+	return string(t), nil
+}
+
+// snakeCaseKeyはキーをスネークケースへ変換します。YAML/TOMLで共通して使用します。
+func snakeCaseKey(key string) (string, error) {
+	t := keyReplacer.Replace(key)
+	tl := strings.Fields(t)
+	for i, w := range tl {
+		tl[i] = strings.ToLower(w)
+	}
+	return strings.Join(tl, "_"), nil
+}
+
+// yamlEncoderはYAML形式のformatEncoderです。
+type yamlEncoder struct{}
+
+// normalizeKeyはYAMLキー名(スネークケース)を返却します。
+func (yamlEncoder) normalizeKey(key string) (string, error) {
+	return snakeCaseKey(key)
+}
+
+func (yamlEncoder) encode(data interface{}) (string, error) {
+	t, e := yaml.Marshal(data)
+	if e != nil {
+		return "", fmt.Errorf("cannot encode YAML: %w", e)
+	}
+	return string(t), nil
+}
+
+// tomlEncoderはTOML形式のformatEncoderです。
+type tomlEncoder struct{}
+
+// normalizeKeyはTOMLキー名(スネークケース)を返却します。
+func (tomlEncoder) normalizeKey(key string) (string, error) {
+	return snakeCaseKey(key)
+}
+
+// TOMLはルートがテーブルでなければならないため、複数メッセージ([]interface{})の場合は
+// "messages"キーの配列として包んでからエンコードします。
+func (tomlEncoder) encode(data interface{}) (string, error) {
+	root := data
+	if messages, ok := data.([]interface{}); ok {
+		root = map[string]interface{}{"messages": messages}
+	}
+	sb := &strings.Builder{}
+	if e := toml.NewEncoder(sb).Encode(root); e != nil {
+		return "", fmt.Errorf("cannot encode TOML: %w", e)
+	}
+	return sb.String(), nil
+}
+
+// xmlEncoderはXML形式のformatEncoderです。
+type xmlEncoder struct{}
+
+var xmlInvalidNameChar = regexp.MustCompile(`[^A-Za-z0-9_.-]`)
+var xmlInvalidLeadingChar = regexp.MustCompile(`^[^A-Za-z_]`)
+
+// normalizeKeyはXML要素名(PascalCase)を有効なXML NCNameへ正規化して返却します。
+func (xmlEncoder) normalizeKey(key string) (string, error) {
+	t := keyReplacer.Replace(key)
+	c := cases.Title(language.Und)
+	t = c.String(t)
+	t = strings.ReplaceAll(t, " ", "")
+	t = xmlInvalidNameChar.ReplaceAllString(t, "_")
+	if xmlInvalidLeadingChar.MatchString(t) {
+		t = "_" + t
+	}
+	if t == "" {
+		return "", errors.New("xml element name is empty")
+	}
+	return t, nil
+}
+
+func (e xmlEncoder) encode(data interface{}) (string, error) {
+	sb := &strings.Builder{}
+	sb.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	if messages, ok := data.([]interface{}); ok {
+		sb.WriteString("<HttpHeaders>\n")
+		for _, m := range messages {
+			writeXmlMessage(sb, m.(map[string]interface{}), 1)
+		}
+		sb.WriteString("</HttpHeaders>\n")
+		return sb.String(), nil
+	}
+	writeXmlMessage(sb, data.(map[string]interface{}), 0)
+	return sb.String(), nil
+}
+
+// writeXmlMessageは1メッセージ分のマップを<HttpHeader>要素として書き出します。
+func writeXmlMessage(sb *strings.Builder, data map[string]interface{}, depth int) {
+	indent := strings.Repeat("  ", depth)
+	fmt.Fprintf(sb, "%s<HttpHeader>\n", indent)
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		writeXmlValue(sb, k, data[k], depth+1)
+	}
+	fmt.Fprintf(sb, "%s</HttpHeader>\n", indent)
+}
+
+// writeXmlValueはvalueをtagという要素名で再帰的に書き出します。
+// []interface{}/[]string は同名要素の並びとして、map[string]interface{} は子要素として展開します。
+func writeXmlValue(sb *strings.Builder, tag string, value interface{}, depth int) {
+	indent := strings.Repeat("  ", depth)
+	switch v := value.(type) {
+	case []string:
+		for _, item := range v {
+			fmt.Fprintf(sb, "%s<%s>%s</%s>\n", indent, tag, xmlEscape(item), tag)
+		}
+	case []interface{}:
+		for _, item := range v {
+			writeXmlValue(sb, tag, item, depth)
+		}
+	case map[string]interface{}:
+		fmt.Fprintf(sb, "%s<%s>\n", indent, tag)
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			nk, e := xmlEncoder{}.normalizeKey(k)
+			if e != nil {
+				nk = "_"
+			}
+			writeXmlValue(sb, nk, v[k], depth+1)
+		}
+		fmt.Fprintf(sb, "%s</%s>\n", indent, tag)
+	default:
+		fmt.Fprintf(sb, "%s<%s>%s</%s>\n", indent, tag, xmlEscape(fmt.Sprint(v)), tag)
+	}
+}
+
+func xmlEscape(s string) string {
+	r := strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+	return r.Replace(s)
+}
@@ -4,7 +4,6 @@ package conv
 
 import (
 	"bufio"
-	"encoding/json"
 	"errors"
 	"log"
 	"os"
@@ -14,9 +13,6 @@ import (
 	"sync"
 	"time"
 	"unicode"
-
-	"golang.org/x/text/cases"
-	"golang.org/x/text/language"
 )
 
 // Formatは入出力フォーマットの列挙型です。
@@ -24,6 +20,9 @@ type Format int
 
 const (
 	JsonFormat Format = iota // JSON形式のフォーマットです。
+	YamlFormat               // YAML形式のフォーマットです。
+	TomlFormat               // TOML形式のフォーマットです。
+	XmlFormat                // XML形式のフォーマットです。
 )
 
 // Format型を文字列に変換します。
@@ -32,15 +31,43 @@ func (f Format) String() string {
 	switch f {
 	case JsonFormat:
 		s = "json"
+	case YamlFormat:
+		s = "yaml"
+	case TomlFormat:
+		s = "toml"
+	case XmlFormat:
+		s = "xml"
+	}
+	return s
+}
+
+// InputFormatは入力データの形式の列挙型です。
+type InputFormat int
+
+const (
+	Http1Text  InputFormat = iota // HTTP/1.xのテキスト形式です。
+	Http2Hpack                    // HTTP/2のHPACK(RFC 7541)形式です。
+)
+
+// InputFormat型を文字列に変換します。
+func (f InputFormat) String() string {
+	s := ""
+	switch f {
+	case Http1Text:
+		s = "http1"
+	case Http2Hpack:
+		s = "http2-hpack"
 	}
 	return s
 }
 
 // HttpHeaderConverterは動作に関する情報を保持します。
 type HttpHeaderConverter struct {
-	rawHeader    *os.File // 生HTTPヘッダです。
-	outputFormat Format   // 出力フォーマットです。
-	numWorker    int      // ワーカー数です。
+	rawHeader        *os.File    // 生HTTPヘッダです。
+	outputFormat     Format      // 出力フォーマットです。
+	inputFormat      InputFormat // 入力フォーマットです。
+	numWorker        int         // ワーカー数です。
+	structuredValues bool        // trueの場合、既知のヘッダ値を入れ子のmap/sliceへ展開します。
 }
 
 // SetRawHeaderは生HTTPヘッダ入力元を設定します。
@@ -53,16 +80,28 @@ func (conv *HttpHeaderConverter) SetOutputFormat(outputFormat Format) {
 	conv.outputFormat = outputFormat
 }
 
+// SetInputFormatは入力フォーマットを指定します。
+func (conv *HttpHeaderConverter) SetInputFormat(inputFormat InputFormat) {
+	conv.inputFormat = inputFormat
+}
+
 // SetNumWorkerはワーカー数を指定します。
 func (conv *HttpHeaderConverter) SetNumWorker(numWorker int) {
 	conv.numWorker = numWorker
 }
 
+// SetStructuredValuesはtrueの場合、Set-Cookie、Cache-Control等の既知のヘッダ値を
+// フラットな文字列ではなく入れ子のmap/sliceへ展開するモードを有効にします。
+func (conv *HttpHeaderConverter) SetStructuredValues(structuredValues bool) {
+	conv.structuredValues = structuredValues
+}
+
 // NewHttpHeaderConverterはHttpHeaderConverterのインスタンスを生成します。
 func NewHttpHeaderConverter() *HttpHeaderConverter {
 	conv := &HttpHeaderConverter{}
 	conv.SetRawHeader(os.Stdin)
 	conv.SetOutputFormat(JsonFormat)
+	conv.SetInputFormat(Http1Text)
 	maxCpu := runtime.NumCPU()
 	conv.SetNumWorker(maxCpu)
 	return conv
@@ -70,16 +109,18 @@ func NewHttpHeaderConverter() *HttpHeaderConverter {
 
 var keyReplacer = strings.NewReplacer("-", " ", "/", " ")
 
-// createJsonKeyはJSONキー名を返却します。
-// JSONキー名はHTTPヘッダのキーより不要な文字を除去し、キャメルケースに変換した結果です。
-func (conv *HttpHeaderConverter) createJsonKey(key string) (string, error) {
-	t := keyReplacer.Replace(key)
-	c := cases.Title(language.Und)
-	t = c.String(t)
-	tl := strings.Split(t, " ")
-	tl[0] = strings.ToLower(tl[0])
-	t = strings.Join(tl, "")
-	return t, nil
+// taggedValueはどのメッセージに属するキーと値かを表します。
+// 入力に複数のメッセージが含まれる場合、messageIndexで出力先のメッセージを区別します。
+type taggedValue struct {
+	messageIndex int
+	key          string
+	value        interface{}
+}
+
+// taggedRawはどのメッセージに属する未変換行かを表します。
+type taggedRaw struct {
+	messageIndex int
+	line         string
 }
 
 var pairPattern *regexp.Regexp = regexp.MustCompile(`^(?P<key>.+?):\s+(?P<value>.+)$`)
@@ -88,8 +129,8 @@ var pairValueIndex = pairPattern.SubexpIndex("value")
 
 // parsePairはキーと値のペアを送信します。
 // 先頭に半角スペースを含まない、かつ、キーと値がペアで定義されているテキストを解釈します。
-// キーは出力フォーマットに合わせて変換します。
-func (conv *HttpHeaderConverter) parsePair(line string, stream chan<- []string) error {
+// キーは出力フォーマットに合わせて変換し、値は構造化モードが有効な場合のみ入れ子に展開します。
+func (conv *HttpHeaderConverter) parsePair(line string, messageIndex int, stream chan<- taggedValue) error {
 	if line[0:1] == " " {
 		return errors.New("not pair string")
 	}
@@ -99,132 +140,261 @@ func (conv *HttpHeaderConverter) parsePair(line string, stream chan<- []string)
 	}
 	k := g[pairKeyIndex]
 	v := g[pairValueIndex]
-	var e error
-	switch conv.outputFormat {
-	case JsonFormat:
-		k, e = conv.createJsonKey(k)
-		if e != nil {
-			return errors.New("cannot convert JSON key")
-		}
-		break
+	value := conv.structuredValue(k, v)
+	enc, e := conv.encoderFor()
+	if e != nil {
+		return e
 	}
-	kv := []string{k, v}
-	stream <- kv
+	k, e = enc.normalizeKey(k)
+	if e != nil {
+		return errors.New("cannot convert header key")
+	}
+	stream <- taggedValue{messageIndex, k, value}
 	return nil
 }
 
-var statusPattern *regexp.Regexp = regexp.MustCompile(`(?P<code>[0-9]{3})\s(?P<message>.+)$`)
+var statusPattern *regexp.Regexp = regexp.MustCompile(`^HTTP/\d+\.\d+\s(?P<code>[0-9]{3})(?:\s(?P<message>.+))?$`)
 var statusCodeIndex = statusPattern.SubexpIndex("code")
 var statusMessageIndex = statusPattern.SubexpIndex("message")
 
-func (conv *HttpHeaderConverter) parseStatus(line string, stream chan<- []string) error {
+func (conv *HttpHeaderConverter) parseStatus(line string, messageIndex int, stream chan<- taggedValue) error {
 	g := statusPattern.FindStringSubmatch(line)
-	log.Println(g)
 	if len(g) != 3 {
 		return errors.New("cannot parse")
 	}
-	var k string
 	v := g[statusCodeIndex]
-	switch conv.outputFormat {
-	case JsonFormat:
-		k = "code"
-	default:
-		k = "Code"
+	enc, e := conv.encoderFor()
+	if e != nil {
+		return e
 	}
-	kv := []string{k, v}
-	stream <- kv
+	k, e := enc.normalizeKey("code")
+	if e != nil {
+		return errors.New("cannot convert header key")
+	}
+	stream <- taggedValue{messageIndex, k, v}
 	return nil
 }
 
-// createSendersは1行単位でチャンネルに文字列配列を送信します。
-// 変換可否によって出力するチャンネルを選択します。
-func (conv *HttpHeaderConverter) createSenders(reader *bufio.Reader) (<-chan []string, <-chan []string, error) {
-	convertedStream := make(chan []string, conv.numWorker)
-	notConvertedStream := make(chan []string, conv.numWorker)
+var requestLinePattern *regexp.Regexp = regexp.MustCompile(`^(?P<method>[A-Z]+)\s(?P<target>\S+)\sHTTP/(?P<version>\d+\.\d+)$`)
+var requestLineMethodIndex = requestLinePattern.SubexpIndex("method")
+var requestLineTargetIndex = requestLinePattern.SubexpIndex("target")
+var requestLineVersionIndex = requestLinePattern.SubexpIndex("version")
+
+// parseRequestLineはHTTPリクエストライン(例: "GET /foo HTTP/1.1")を解釈し、
+// method、path、httpVersionの3つのキーを送信します。
+func (conv *HttpHeaderConverter) parseRequestLine(line string, messageIndex int, stream chan<- taggedValue) error {
+	g := requestLinePattern.FindStringSubmatch(line)
+	if len(g) != 4 {
+		return errors.New("cannot parse")
+	}
+	enc, e := conv.encoderFor()
+	if e != nil {
+		return e
+	}
+	fields := []struct {
+		name  string
+		value string
+	}{
+		{"method", g[requestLineMethodIndex]},
+		{"path", g[requestLineTargetIndex]},
+		{"HTTP-Version", g[requestLineVersionIndex]},
+	}
+	for _, f := range fields {
+		k, e := enc.normalizeKey(f.name)
+		if e != nil {
+			return errors.New("cannot convert header key")
+		}
+		stream <- taggedValue{messageIndex, k, f.value}
+	}
+	return nil
+}
+
+// createSendersはメッセージ単位(空行区切り)に行を読み取り、チャンネルへキーと値を送信します。
+// RFC 7230のobs-fold(継続行)は前の論理行へ結合してから解釈します。
+func (conv *HttpHeaderConverter) createSenders(reader *bufio.Reader) (<-chan taggedValue, <-chan taggedRaw, error) {
+	convertedStream := make(chan taggedValue, conv.numWorker)
+	notConvertedStream := make(chan taggedRaw, conv.numWorker)
 	go func() {
 		defer close(convertedStream)
 		defer close(notConvertedStream)
-		f := true
+		messageIndex := 0
+		var lines []string
+		flush := func() {
+			if len(lines) == 0 {
+				return
+			}
+			conv.parseMessage(messageIndex, lines, convertedStream, notConvertedStream)
+			messageIndex++
+			lines = nil
+		}
 		for {
 			l, e := reader.ReadString('\n')
 			if e != nil {
 				break
 			}
-			l = strings.TrimRightFunc(l, unicode.IsSpace)
-			if len(l) < 4 {
+			trimmed := strings.TrimRightFunc(l, unicode.IsSpace)
+			if trimmed == "" {
+				flush()
 				continue
 			}
-			if f {
-				f = false
-				e = conv.parseStatus(l, convertedStream)
-				if e == nil {
-					continue
-				}
+			if len(lines) > 0 && (strings.HasPrefix(l, " ") || strings.HasPrefix(l, "\t")) {
+				lines[len(lines)-1] += " " + strings.TrimSpace(trimmed)
+				continue
 			}
-			e = conv.parsePair(l, convertedStream)
-			if e == nil {
+			if len(trimmed) < 4 {
 				continue
 			}
-			notConvertedStream <- []string{l}
+			lines = append(lines, trimmed)
 		}
+		flush()
 	}()
 	return convertedStream, notConvertedStream, nil
 }
 
-// convertは変換した結果を返却します。
-func (conv *HttpHeaderConverter) convert() (map[string]interface{}, []string, time.Duration, error) {
+// parseMessageは1メッセージ分の論理行(obs-fold結合済み)を解釈し、結果をstreamへ送信します。
+func (conv *HttpHeaderConverter) parseMessage(messageIndex int, lines []string, stream chan<- taggedValue, notConverted chan<- taggedRaw) {
+	for i, l := range lines {
+		if i == 0 {
+			if e := conv.parseStatus(l, messageIndex, stream); e == nil {
+				continue
+			}
+			if e := conv.parseRequestLine(l, messageIndex, stream); e == nil {
+				continue
+			}
+		}
+		if e := conv.parsePair(l, messageIndex, stream); e == nil {
+			continue
+		}
+		notConverted <- taggedRaw{messageIndex, l}
+	}
+}
+
+// mergeConvertedはkeyの値をmへ格納します。既に同名キーが存在する場合は配列へ統合し、
+// 同じヘッダ名が複数回出現した際に後勝ちの上書きで値が失われることを防ぎます。
+func mergeConverted(m map[string]interface{}, key string, value interface{}) {
+	existing, ok := m[key]
+	if !ok {
+		m[key] = value
+		return
+	}
+	if merged, ok := existing.([]interface{}); ok {
+		m[key] = append(merged, value)
+		return
+	}
+	m[key] = []interface{}{existing, value}
+}
+
+// convertは変換した結果をメッセージ単位の配列で返却します。入力が単一メッセージの場合でも
+// 要素数1の配列を返し、呼び出し元(OutputWithRaw)が単一メッセージかどうかを判定します。
+func (conv *HttpHeaderConverter) convert() ([]map[string]interface{}, [][]string, time.Duration, error) {
 	// 生ヘッダ送信ワーカー生成
 	beginTime := time.Now()
-	r := bufio.NewReader(conv.rawHeader)
-	convetedStream, notConvertedStream, _ := conv.createSenders(r)
+	var convetedStream <-chan taggedValue
+	var notConvertedStream <-chan taggedRaw
+	var e error
+	switch conv.inputFormat {
+	case Http2Hpack:
+		convetedStream, notConvertedStream, e = conv.createHpackSenders(conv.rawHeader)
+	default:
+		r := bufio.NewReader(conv.rawHeader)
+		convetedStream, notConvertedStream, e = conv.createSenders(r)
+	}
+	if e != nil {
+		return nil, nil, 0, e
+	}
 
 	// 処理済み文字列配列マップ化
-	wg := &sync.WaitGroup{}
-	receiver := func(stream <-chan []string, receiver func(...string)) {
-		defer wg.Done()
-		for s := range stream {
-			receiver(s...)
+	var mu sync.Mutex
+	messages := make([]map[string]interface{}, 0)
+	rawLines := make([][]string, 0)
+	ensureMessage := func(messageIndex int) {
+		for len(messages) <= messageIndex {
+			messages = append(messages, make(map[string]interface{}))
+			rawLines = append(rawLines, make([]string, 0))
 		}
 	}
-	converted := make(map[string]interface{})
+	wg := &sync.WaitGroup{}
 	wg.Add(1)
-	go receiver(convetedStream, func(s ...string) {
-		converted[s[0]] = s[1]
-	})
-	notConveted := make([]string, 0)
+	go func() {
+		defer wg.Done()
+		for tv := range convetedStream {
+			mu.Lock()
+			ensureMessage(tv.messageIndex)
+			mergeConverted(messages[tv.messageIndex], tv.key, tv.value)
+			mu.Unlock()
+		}
+	}()
 	wg.Add(1)
-	go receiver(notConvertedStream, func(s ...string) {
-		notConveted = append(notConveted, s[0])
-	})
+	go func() {
+		defer wg.Done()
+		for tr := range notConvertedStream {
+			mu.Lock()
+			ensureMessage(tr.messageIndex)
+			rawLines[tr.messageIndex] = append(rawLines[tr.messageIndex], tr.line)
+			mu.Unlock()
+		}
+	}()
 	wg.Wait()
 
+	if len(messages) == 0 {
+		messages = append(messages, make(map[string]interface{}))
+		rawLines = append(rawLines, make([]string, 0))
+	}
+
 	// 実行時間算出
 	endTime := time.Now()
 	processTime := endTime.Sub(beginTime)
-	return converted, notConveted, processTime, nil
+	return messages, rawLines, processTime, nil
 }
 
 // Outputは変換した結果を返却します。
 func (conv *HttpHeaderConverter) Output() (string, error) {
+	resp, _, _, e := conv.OutputWithRaw()
+	return resp, e
+}
+
+// OutputWithRawは変換した結果に加え、変換できなかった行と処理時間を返却します。
+// 呼び出し元がログ出力以外の用途(サーバのレスポンス等)でこれらを必要とする場合に使用します。
+// 入力に複数のメッセージが含まれていた場合、変換結果はメッセージ毎のオブジェクトの配列として
+// 出力されます(単一メッセージの場合は従来通りオブジェクト単体です)。
+func (conv *HttpHeaderConverter) OutputWithRaw() (string, []string, time.Duration, error) {
 	// マップ生成
-	converted, notConverted, p, e := conv.convert()
+	messages, rawLines, p, e := conv.convert()
 	if e != nil {
-		return "", e
+		return "", nil, 0, e
 	}
 	log.Println(p)
 
-	// 出力フォーマット処理
-	if len(notConverted) > 0 {
-		var k string
-		switch conv.outputFormat {
-		case JsonFormat:
-			k = "raw"
-		default:
-			k = "Raw"
+	enc, e := conv.encoderFor()
+	if e != nil {
+		return "", nil, 0, e
+	}
+	rawKey, e := enc.normalizeKey("raw")
+	if e != nil {
+		return "", nil, 0, e
+	}
+	allRaw := make([]string, 0)
+	for i, raw := range rawLines {
+		if len(raw) > 0 {
+			messages[i][rawKey] = raw
+			allRaw = append(allRaw, raw...)
+		}
+	}
+
+	// 出力フォーマット処理: 単一メッセージの場合は後方互換のためオブジェクト単体、
+	// 複数メッセージの場合はオブジェクトの配列として出力します。
+	var resp string
+	if len(messages) == 1 {
+		resp, e = enc.encode(messages[0])
+	} else {
+		data := make([]interface{}, len(messages))
+		for i, m := range messages {
+			data[i] = m
 		}
-		converted[k] = notConverted
+		resp, e = enc.encode(data)
+	}
+	if e != nil {
+		return "", nil, 0, e
 	}
-	t, _ := json.Marshal(converted)
-	resp := string(t)
-	return resp, nil
+	return resp, allRaw, p, nil
 }
@@ -0,0 +1,349 @@
+// Copyright (c) 2022 RFull Development
+// This source code is managed under the MIT license. See LICENSE in the project root.
+package conv
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// defaultHeaderTableSizeはSETTINGS_HEADER_TABLE_SIZEの初期値です。
+const defaultHeaderTableSize = 4096
+
+// hpackEntryは動的テーブル及び静的テーブルのエントリです。
+type hpackEntry struct {
+	name  string
+	value string
+}
+
+// sizeはRFC 7541で定義されたエントリのサイズです。
+func (e hpackEntry) size() int {
+	return len(e.name) + len(e.value) + 32
+}
+
+// hpackStaticTableはRFC 7541 Appendix Aの静的テーブルです。
+var hpackStaticTable = [61]hpackEntry{
+	{":authority", ""},
+	{":method", "GET"},
+	{":method", "POST"},
+	{":path", "/"},
+	{":path", "/index.html"},
+	{":scheme", "http"},
+	{":scheme", "https"},
+	{":status", "200"},
+	{":status", "204"},
+	{":status", "206"},
+	{":status", "304"},
+	{":status", "400"},
+	{":status", "404"},
+	{":status", "500"},
+	{"accept-charset", ""},
+	{"accept-encoding", "gzip, deflate"},
+	{"accept-language", ""},
+	{"accept-ranges", ""},
+	{"accept", ""},
+	{"access-control-allow-origin", ""},
+	{"age", ""},
+	{"allow", ""},
+	{"authorization", ""},
+	{"cache-control", ""},
+	{"content-disposition", ""},
+	{"content-encoding", ""},
+	{"content-language", ""},
+	{"content-length", ""},
+	{"content-location", ""},
+	{"content-range", ""},
+	{"content-type", ""},
+	{"cookie", ""},
+	{"date", ""},
+	{"etag", ""},
+	{"expect", ""},
+	{"expires", ""},
+	{"from", ""},
+	{"host", ""},
+	{"if-match", ""},
+	{"if-modified-since", ""},
+	{"if-none-match", ""},
+	{"if-range", ""},
+	{"if-unmodified-since", ""},
+	{"last-modified", ""},
+	{"link", ""},
+	{"location", ""},
+	{"max-forwards", ""},
+	{"proxy-authenticate", ""},
+	{"proxy-authorization", ""},
+	{"range", ""},
+	{"referer", ""},
+	{"refresh", ""},
+	{"retry-after", ""},
+	{"server", ""},
+	{"set-cookie", ""},
+	{"strict-transport-security", ""},
+	{"transfer-encoding", ""},
+	{"user-agent", ""},
+	{"vary", ""},
+	{"via", ""},
+	{"www-authenticate", ""},
+}
+
+// hpackDynamicTableはRFC 7541で定義された動的テーブルです。
+// 追加順と逆順(先頭が最新)に保持するFIFOとして振る舞います。
+type hpackDynamicTable struct {
+	entries []hpackEntry
+	maxSize int
+	size    int
+}
+
+// newHpackDynamicTableはhpackDynamicTableのインスタンスを生成します。
+func newHpackDynamicTable() *hpackDynamicTable {
+	return &hpackDynamicTable{maxSize: defaultHeaderTableSize}
+}
+
+// addは動的テーブルの先頭にエントリを追加し、上限を超える古いエントリを追い出します。
+func (t *hpackDynamicTable) add(e hpackEntry) {
+	t.entries = append([]hpackEntry{e}, t.entries...)
+	t.size += e.size()
+	t.evict()
+}
+
+// evictは上限サイズを超えた分の古いエントリを追い出します。
+func (t *hpackDynamicTable) evict() {
+	for t.size > t.maxSize && len(t.entries) > 0 {
+		last := t.entries[len(t.entries)-1]
+		t.entries = t.entries[:len(t.entries)-1]
+		t.size -= last.size()
+	}
+}
+
+// setMaxSizeは動的テーブルの上限サイズを変更します。
+func (t *hpackDynamicTable) setMaxSize(maxSize int) error {
+	if maxSize < 0 {
+		return fmt.Errorf("hpack: invalid dynamic table size update %d", maxSize)
+	}
+	t.maxSize = maxSize
+	t.evict()
+	return nil
+}
+
+// getはインデックス(動的テーブル内、1始まり)のエントリを取得します。
+func (t *hpackDynamicTable) get(index int) (hpackEntry, error) {
+	if index < 1 || index > len(t.entries) {
+		return hpackEntry{}, fmt.Errorf("hpack: dynamic table index %d out of range", index)
+	}
+	return t.entries[index-1], nil
+}
+
+// hpackEntryAtは静的テーブルと動的テーブルを合わせたインデックス(1始まり)からエントリを取得します。
+func hpackEntryAt(index int, dynamic *hpackDynamicTable) (hpackEntry, error) {
+	if index < 1 {
+		return hpackEntry{}, fmt.Errorf("hpack: invalid index 0")
+	}
+	if index <= len(hpackStaticTable) {
+		return hpackStaticTable[index-1], nil
+	}
+	return dynamic.get(index - len(hpackStaticTable))
+}
+
+// decodeHpackIntegerはRFC 7541 5.1節の変長整数表現をデコードします。
+// prefixBitsは先頭バイトのうち値に使用されるビット数です。
+func decodeHpackInteger(data []byte, prefixBits int) (int, int, error) {
+	if len(data) == 0 {
+		return 0, 0, fmt.Errorf("hpack: empty integer")
+	}
+	mask := byte((1 << uint(prefixBits)) - 1)
+	value := int(data[0] & mask)
+	if value < int(mask) {
+		return value, 1, nil
+	}
+	m := 0
+	i := 1
+	for {
+		if i >= len(data) {
+			return 0, 0, fmt.Errorf("hpack: truncated integer")
+		}
+		b := data[i]
+		value += int(b&0x7f) << uint(m)
+		if value < 0 {
+			return 0, 0, fmt.Errorf("hpack: integer overflow")
+		}
+		i++
+		if b&0x80 == 0 {
+			break
+		}
+		m += 7
+		if m > 63 {
+			return 0, 0, fmt.Errorf("hpack: integer overflow")
+		}
+	}
+	return value, i, nil
+}
+
+// decodeHpackStringはRFC 7541 5.2節の文字列リテラルをデコードします。
+func decodeHpackString(data []byte) (string, int, error) {
+	if len(data) == 0 {
+		return "", 0, fmt.Errorf("hpack: empty string literal")
+	}
+	huffman := data[0]&0x80 != 0
+	length, consumed, e := decodeHpackInteger(data, 7)
+	if e != nil {
+		return "", 0, fmt.Errorf("hpack: cannot decode string length: %w", e)
+	}
+	if consumed+length > len(data) {
+		return "", 0, fmt.Errorf("hpack: truncated string literal")
+	}
+	raw := data[consumed : consumed+length]
+	if !huffman {
+		return string(raw), consumed + length, nil
+	}
+	s, e := huffmanDecode(raw)
+	if e != nil {
+		return "", 0, fmt.Errorf("hpack: cannot decode huffman string: %w", e)
+	}
+	return s, consumed + length, nil
+}
+
+// hpackHeaderBlockDecoderはHPACKヘッダブロックを1つずつデコードするデコーダです。
+type hpackHeaderBlockDecoder struct {
+	dynamic *hpackDynamicTable
+}
+
+// newHpackHeaderBlockDecoderはhpackHeaderBlockDecoderのインスタンスを生成します。
+func newHpackHeaderBlockDecoder() *hpackHeaderBlockDecoder {
+	return &hpackHeaderBlockDecoder{dynamic: newHpackDynamicTable()}
+}
+
+// decodeはヘッダブロック全体をデコードし、(name, value)のペアを順番に返却します。
+func (d *hpackHeaderBlockDecoder) decode(data []byte) ([]hpackEntry, error) {
+	entries := make([]hpackEntry, 0)
+	for len(data) > 0 {
+		b := data[0]
+		var e hpackEntry
+		var consumed int
+		var err error
+		switch {
+		case b&0x80 != 0: // 1xxxxxxx: インデックスヘッダフィールド
+			e, consumed, err = d.decodeIndexed(data)
+		case b&0xc0 == 0x40: // 01xxxxxx: インクリメンタルインデックス付きリテラル
+			e, consumed, err = d.decodeLiteral(data, 6, true)
+		case b&0xf0 == 0x00: // 0000xxxx: インデックスなしリテラル
+			e, consumed, err = d.decodeLiteral(data, 4, false)
+		case b&0xf0 == 0x10: // 0001xxxx: 再インデックス禁止リテラル
+			e, consumed, err = d.decodeLiteral(data, 4, false)
+		case b&0xe0 == 0x20: // 001xxxxx: 動的テーブルサイズ更新
+			consumed, err = d.decodeSizeUpdate(data)
+			if err == nil {
+				data = data[consumed:]
+				continue
+			}
+		default:
+			err = fmt.Errorf("hpack: unknown representation 0x%02x", b)
+		}
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+		data = data[consumed:]
+	}
+	return entries, nil
+}
+
+// decodeIndexedはインデックスヘッダフィールド表現をデコードします。
+func (d *hpackHeaderBlockDecoder) decodeIndexed(data []byte) (hpackEntry, int, error) {
+	index, consumed, e := decodeHpackInteger(data, 7)
+	if e != nil {
+		return hpackEntry{}, 0, fmt.Errorf("hpack: cannot decode indexed field: %w", e)
+	}
+	if index == 0 {
+		return hpackEntry{}, 0, fmt.Errorf("hpack: indexed field index 0 is invalid")
+	}
+	entry, e := hpackEntryAt(index, d.dynamic)
+	if e != nil {
+		return hpackEntry{}, 0, e
+	}
+	return entry, consumed, nil
+}
+
+// decodeLiteralはリテラルヘッダフィールド表現(インデックス付き/なし/禁止)をデコードします。
+func (d *hpackHeaderBlockDecoder) decodeLiteral(data []byte, prefixBits int, index bool) (hpackEntry, int, error) {
+	nameIndex, consumed, e := decodeHpackInteger(data, prefixBits)
+	if e != nil {
+		return hpackEntry{}, 0, fmt.Errorf("hpack: cannot decode literal name index: %w", e)
+	}
+	var name string
+	if nameIndex == 0 {
+		n, c, e := decodeHpackString(data[consumed:])
+		if e != nil {
+			return hpackEntry{}, 0, e
+		}
+		name = n
+		consumed += c
+	} else {
+		entry, e := hpackEntryAt(nameIndex, d.dynamic)
+		if e != nil {
+			return hpackEntry{}, 0, e
+		}
+		name = entry.name
+	}
+	value, c, e := decodeHpackString(data[consumed:])
+	if e != nil {
+		return hpackEntry{}, 0, e
+	}
+	consumed += c
+	entry := hpackEntry{name: name, value: value}
+	if index {
+		d.dynamic.add(entry)
+	}
+	return entry, consumed, nil
+}
+
+// decodeSizeUpdateは動的テーブルサイズ更新表現をデコードします。
+func (d *hpackHeaderBlockDecoder) decodeSizeUpdate(data []byte) (int, error) {
+	size, consumed, e := decodeHpackInteger(data, 5)
+	if e != nil {
+		return 0, fmt.Errorf("hpack: cannot decode dynamic table size update: %w", e)
+	}
+	if size > defaultHeaderTableSize {
+		return 0, fmt.Errorf("hpack: dynamic table size update %d exceeds limit", size)
+	}
+	if e := d.dynamic.setMaxSize(size); e != nil {
+		return 0, e
+	}
+	return consumed, nil
+}
+
+// createHpackSendersはHPACKでエンコードされたヘッダブロックをデコードし、createSendersと同じ形式の
+// チャンネルへ(name, value)のペアを送信します。疑似ヘッダ(":status"等)は先頭のコロンを除去します。
+// HPACKのヘッダブロックは常に単一メッセージ(messageIndex 0)として扱います。
+func (conv *HttpHeaderConverter) createHpackSenders(raw *os.File) (<-chan taggedValue, <-chan taggedRaw, error) {
+	data, e := io.ReadAll(raw)
+	if e != nil {
+		return nil, nil, fmt.Errorf("hpack: cannot read raw header: %w", e)
+	}
+	decoder := newHpackHeaderBlockDecoder()
+	entries, e := decoder.decode(data)
+	if e != nil {
+		return nil, nil, fmt.Errorf("hpack: cannot decode header block: %w", e)
+	}
+	enc, e := conv.encoderFor()
+	if e != nil {
+		return nil, nil, e
+	}
+	convertedStream := make(chan taggedValue, len(entries)+1)
+	notConvertedStream := make(chan taggedRaw, 1)
+	for _, entry := range entries {
+		name := strings.TrimPrefix(entry.name, ":")
+		value := conv.structuredValue(name, entry.value)
+		k, e := enc.normalizeKey(name)
+		if e != nil {
+			close(convertedStream)
+			close(notConvertedStream)
+			return nil, nil, fmt.Errorf("hpack: cannot convert header key: %w", e)
+		}
+		convertedStream <- taggedValue{0, k, value}
+	}
+	close(convertedStream)
+	close(notConvertedStream)
+	return convertedStream, notConvertedStream, nil
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2022 RFull Development
+// This source code is managed under the MIT license. See LICENSE in the project root.
+package conv
+
+import "testing"
+
+func TestHuffmanDecode(t *testing.T) {
+	cases := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{
+			name: "RFC 7541 C.4.2 www.example.com",
+			data: []byte{0xf1, 0xe3, 0xc2, 0xe5, 0xf2, 0x3a, 0x6b, 0xa0, 0xab, 0x90, 0xf4, 0xff},
+			want: "www.example.com",
+		},
+		{
+			name: "single symbol, padding shorter than 8 bits",
+			data: []byte{0x1f},
+			want: "a",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, e := huffmanDecode(c.data)
+			if e != nil {
+				t.Fatalf("unexpected error: %v", e)
+			}
+			if got != c.want {
+				t.Errorf("got %q, want %q", got, c.want)
+			}
+		})
+	}
+}
+
+func TestHuffmanDecodeInvalidPadding(t *testing.T) {
+	cases := []struct {
+		name string
+		data []byte
+	}{
+		{
+			name: "padding longer than 7 bits",
+			data: []byte{0x1f, 0xff},
+		},
+		{
+			name: "padding is not all ones",
+			data: []byte{0x18},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if _, e := huffmanDecode(c.data); e == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2022 RFull Development
+// This source code is managed under the MIT license. See LICENSE in the project root.
+package conv
+
+import "testing"
+
+func TestDecodeHpackInteger(t *testing.T) {
+	cases := []struct {
+		name       string
+		data       []byte
+		prefixBits int
+		wantValue  int
+		wantRead   int
+	}{
+		{"fits in prefix", []byte{0x0a}, 5, 10, 1},
+		{"RFC 7541 C.1.3 1337 with 5-bit prefix", []byte{0x1f, 0x9a, 0x0a}, 5, 1337, 3},
+		{"zero", []byte{0x00}, 7, 0, 1},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			v, n, e := decodeHpackInteger(c.data, c.prefixBits)
+			if e != nil {
+				t.Fatalf("unexpected error: %v", e)
+			}
+			if v != c.wantValue || n != c.wantRead {
+				t.Errorf("got (%d, %d), want (%d, %d)", v, n, c.wantValue, c.wantRead)
+			}
+		})
+	}
+}
+
+func TestDecodeHpackIntegerMalformed(t *testing.T) {
+	cases := []struct {
+		name       string
+		data       []byte
+		prefixBits int
+	}{
+		{"empty input", []byte{}, 5},
+		{"truncated continuation", []byte{0x1f}, 5},
+		{"overflow", []byte{0x1f, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f}, 5},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if _, _, e := decodeHpackInteger(c.data, c.prefixBits); e == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestDecodeHpackString(t *testing.T) {
+	cases := []struct {
+		name     string
+		data     []byte
+		want     string
+		wantRead int
+	}{
+		{
+			name:     "plain text",
+			data:     append([]byte{0x0f}, "www.example.com"...),
+			want:     "www.example.com",
+			wantRead: 16,
+		},
+		{
+			name:     "huffman encoded",
+			data:     append([]byte{0x8c}, 0xf1, 0xe3, 0xc2, 0xe5, 0xf2, 0x3a, 0x6b, 0xa0, 0xab, 0x90, 0xf4, 0xff),
+			want:     "www.example.com",
+			wantRead: 13,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, n, e := decodeHpackString(c.data)
+			if e != nil {
+				t.Fatalf("unexpected error: %v", e)
+			}
+			if got != c.want || n != c.wantRead {
+				t.Errorf("got (%q, %d), want (%q, %d)", got, n, c.want, c.wantRead)
+			}
+		})
+	}
+}
+
+func TestDecodeHpackStringTruncated(t *testing.T) {
+	data := []byte{0x0f, 'a', 'b'}
+	if _, _, e := decodeHpackString(data); e == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestHpackHeaderBlockDecode(t *testing.T) {
+	// RFC 7541 C.4.1: first request, indexed static entries plus a literal
+	// with incremental indexing referencing a static name and a Huffman value.
+	data := []byte{0x82, 0x86, 0x84, 0x41, 0x8c, 0xf1, 0xe3, 0xc2, 0xe5, 0xf2, 0x3a, 0x6b, 0xa0, 0xab, 0x90, 0xf4, 0xff}
+	d := newHpackHeaderBlockDecoder()
+	entries, e := d.decode(data)
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+	want := []hpackEntry{
+		{":method", "GET"},
+		{":scheme", "http"},
+		{":path", "/"},
+		{":authority", "www.example.com"},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("got %d entries, want %d", len(entries), len(want))
+	}
+	for i, w := range want {
+		if entries[i] != w {
+			t.Errorf("entry %d: got %+v, want %+v", i, entries[i], w)
+		}
+	}
+	// The literal with incremental indexing must have been added to the
+	// dynamic table as the newest (lowest-index) entry.
+	got, e := d.dynamic.get(1)
+	if e != nil {
+		t.Fatalf("unexpected error reading dynamic table: %v", e)
+	}
+	if got != want[3] {
+		t.Errorf("dynamic table entry 1 = %+v, want %+v", got, want[3])
+	}
+}
+
+func TestHpackDynamicTableSizeUpdate(t *testing.T) {
+	// 0x3e = 001 11110: dynamic table size update to 30.
+	data := []byte{0x3e}
+	d := newHpackHeaderBlockDecoder()
+	if _, e := d.decode(data); e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+	if d.dynamic.maxSize != 30 {
+		t.Errorf("maxSize = %d, want 30", d.dynamic.maxSize)
+	}
+}
+
+func TestHpackDynamicTableEviction(t *testing.T) {
+	table := newHpackDynamicTable()
+	if e := table.setMaxSize(60); e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+	table.add(hpackEntry{name: "a", value: "1"}) // size 34
+	table.add(hpackEntry{name: "b", value: "1"}) // size 34, evicts "a" (34+34 > 60)
+
+	got, e := table.get(1)
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+	if got != (hpackEntry{name: "b", value: "1"}) {
+		t.Errorf("get(1) = %+v, want {b 1}", got)
+	}
+	if _, e := table.get(2); e == nil {
+		t.Fatal("expected out-of-range error for evicted entry, got nil")
+	}
+}
+
+func TestHpackHeaderBlockDecodeMalformed(t *testing.T) {
+	cases := []struct {
+		name string
+		data []byte
+	}{
+		{"indexed field index 0", []byte{0x80}},
+		{"dynamic table size update exceeds limit", []byte{0x3f, 0xe2, 0x1f}},
+		{"indexed field out of range", []byte{0xfe}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			d := newHpackHeaderBlockDecoder()
+			if _, e := d.decode(c.data); e == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+	}
+}
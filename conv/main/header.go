@@ -4,12 +4,24 @@ package main
 
 import (
 	"fmt"
+	"log"
 	"os"
 
 	"github.com/ngv-jp/libgo-http/conv"
+	"github.com/ngv-jp/libgo-http/conv/server"
+)
+
+// grpcAddrとrestAddrは`server`サブコマンドで起動する各リスナーの既定アドレスです。
+const (
+	grpcAddr = ":50051"
+	restAddr = ":8080"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "server" {
+		runServer()
+		return
+	}
 	c := conv.NewHttpHeaderConverter()
 	r, e := c.Output()
 	if e != nil {
@@ -17,3 +29,17 @@ func main() {
 	}
 	fmt.Println(r)
 }
+
+// runServerはgRPCサービスとRESTゲートウェイを起動し続けます。
+func runServer() {
+	go func() {
+		log.Printf("REST gateway listening on %s", restAddr)
+		if e := server.ListenAndServeRest(restAddr); e != nil {
+			log.Fatalf("REST gateway stopped: %v", e)
+		}
+	}()
+	log.Printf("gRPC service listening on %s", grpcAddr)
+	if e := server.ListenAndServeGrpc(grpcAddr); e != nil {
+		log.Fatalf("gRPC service stopped: %v", e)
+	}
+}
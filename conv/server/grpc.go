@@ -0,0 +1,23 @@
+// Copyright (c) 2022 RFull Development
+// This source code is managed under the MIT license. See LICENSE in the project root.
+package server
+
+import (
+	"fmt"
+	"net"
+
+	"google.golang.org/grpc"
+
+	pb "github.com/ngv-jp/libgo-http/proto"
+)
+
+// ListenAndServeGrpcは指定のアドレスでHeaderConverterのgRPCサービスを起動します。
+func ListenAndServeGrpc(addr string) error {
+	lis, e := net.Listen("tcp", addr)
+	if e != nil {
+		return fmt.Errorf("cannot listen on %s: %w", addr, e)
+	}
+	s := grpc.NewServer()
+	pb.RegisterHeaderConverterServer(s, NewServer())
+	return s.Serve(lis)
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2022 RFull Development
+// This source code is managed under the MIT license. See LICENSE in the project root.
+package server
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	pb "github.com/ngv-jp/libgo-http/proto"
+)
+
+// ListenAndServeRestは指定のアドレスでREST APIゲートウェイを起動します。
+func ListenAndServeRest(addr string) error {
+	return http.ListenAndServe(addr, NewRestHandler())
+}
+
+// restContentTypeはpb.OutputFormat毎のレスポンスContent-Typeです。
+var restContentType = map[pb.OutputFormat]string{
+	pb.OutputFormat_JSON: "application/json",
+	pb.OutputFormat_YAML: "application/yaml",
+	pb.OutputFormat_TOML: "application/toml",
+	pb.OutputFormat_XML:  "application/xml",
+}
+
+// restInputFormatParams はクエリパラメータ値からpb.InputFormatへの対応表です。
+var restInputFormatParams = map[string]pb.InputFormat{
+	"http1":       pb.InputFormat_HTTP1_TEXT,
+	"http2-hpack": pb.InputFormat_HTTP2_HPACK,
+}
+
+// restOutputFormatParamsはクエリパラメータ値からpb.OutputFormatへの対応表です。
+var restOutputFormatParams = map[string]pb.OutputFormat{
+	"json": pb.OutputFormat_JSON,
+	"yaml": pb.OutputFormat_YAML,
+	"toml": pb.OutputFormat_TOML,
+	"xml":  pb.OutputFormat_XML,
+}
+
+// NewRestHandlerは`POST /v1/convert`を受け付けるREST APIゲートウェイを生成します。
+// 生ヘッダはリクエストボディとして受け取り、入出力フォーマットはクエリパラメータ
+// (`input`, `output`)で指定します。変換結果は本文に、処理時間と未変換行数はレスポンス
+// ヘッダ(`X-Process-Time-Seconds`, `X-Raw-Count`)に格納します。
+func NewRestHandler() http.Handler {
+	s := NewServer()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/convert", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		raw, e := io.ReadAll(r.Body)
+		if e != nil {
+			http.Error(w, fmt.Sprintf("cannot read request body: %v", e), http.StatusBadRequest)
+			return
+		}
+		req := &pb.ConvertRequest{
+			RawHeader:    raw,
+			InputFormat:  restInputFormatParams[r.URL.Query().Get("input")],
+			OutputFormat: restOutputFormatParams[r.URL.Query().Get("output")],
+		}
+		if n, e := strconv.Atoi(r.URL.Query().Get("num_worker")); e == nil {
+			req.NumWorker = int32(n)
+		}
+		resp, e := s.convert(req)
+		if e != nil {
+			http.Error(w, e.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", restContentType[req.OutputFormat])
+		w.Header().Set("X-Process-Time-Seconds", strconv.FormatFloat(resp.ProcessTimeSeconds, 'f', -1, 64))
+		w.Header().Set("X-Raw-Count", strconv.Itoa(len(resp.Raw)))
+		w.Write([]byte(resp.Body))
+	})
+	return mux
+}
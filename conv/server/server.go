@@ -0,0 +1,110 @@
+// Copyright (c) 2022 RFull Development
+// This source code is managed under the MIT license. See LICENSE in the project root.
+
+// Package serverはHttpHeaderConverterをgRPC/REST経由で公開するための常駐サービスです。
+// メッセージ定義はproto/header.protoで管理し、`go generate ./...`でスタブを生成します。
+package server
+
+//go:generate protoc --go_out=../.. --go_opt=module=github.com/ngv-jp/libgo-http --go-grpc_out=../.. --go-grpc_opt=module=github.com/ngv-jp/libgo-http -I ../../proto ../../proto/header.proto
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ngv-jp/libgo-http/conv"
+	pb "github.com/ngv-jp/libgo-http/proto"
+)
+
+// Serverはリクエスト毎にHttpHeaderConverterを生成し変換処理を委譲します。
+type Server struct {
+	pb.UnimplementedHeaderConverterServer
+}
+
+// NewServerはServerのインスタンスを生成します。
+func NewServer() *Server {
+	return &Server{}
+}
+
+// converterForはリクエストの内容に応じたHttpHeaderConverterを生成します。
+func (s *Server) converterFor(raw *os.File, inputFormat pb.InputFormat, outputFormat pb.OutputFormat, numWorker int32) *conv.HttpHeaderConverter {
+	c := conv.NewHttpHeaderConverter()
+	c.SetRawHeader(raw)
+	c.SetInputFormat(toConvInputFormat(inputFormat))
+	c.SetOutputFormat(toConvOutputFormat(outputFormat))
+	if numWorker > 0 {
+		c.SetNumWorker(int(numWorker))
+	}
+	return c
+}
+
+// toConvInputFormatはpb.InputFormatをconv.InputFormatへ変換します。
+func toConvInputFormat(f pb.InputFormat) conv.InputFormat {
+	if f == pb.InputFormat_HTTP2_HPACK {
+		return conv.Http2Hpack
+	}
+	return conv.Http1Text
+}
+
+// toConvOutputFormatはpb.OutputFormatをconv.Formatへ変換します。
+func toConvOutputFormat(f pb.OutputFormat) conv.Format {
+	switch f {
+	case pb.OutputFormat_YAML:
+		return conv.YamlFormat
+	case pb.OutputFormat_TOML:
+		return conv.TomlFormat
+	case pb.OutputFormat_XML:
+		return conv.XmlFormat
+	default:
+		return conv.JsonFormat
+	}
+}
+
+// convertはConvertRequestの生ヘッダをパイプ経由でHttpHeaderConverterへ流し込み、変換結果を返却します。
+func (s *Server) convert(req *pb.ConvertRequest) (*pb.ConvertResponse, error) {
+	r, w, e := os.Pipe()
+	if e != nil {
+		return nil, fmt.Errorf("cannot create pipe: %w", e)
+	}
+	go func() {
+		defer w.Close()
+		io.Copy(w, bytes.NewReader(req.RawHeader))
+	}()
+	c := s.converterFor(r, req.InputFormat, req.OutputFormat, req.NumWorker)
+	body, raw, processTime, e := c.OutputWithRaw()
+	if e != nil {
+		return nil, fmt.Errorf("cannot convert header: %w", e)
+	}
+	return &pb.ConvertResponse{
+		Body:               body,
+		Raw:                raw,
+		ProcessTimeSeconds: processTime.Seconds(),
+	}, nil
+}
+
+// Convertは1回分の生HTTPヘッダを変換します。
+func (s *Server) Convert(ctx context.Context, req *pb.ConvertRequest) (*pb.ConvertResponse, error) {
+	return s.convert(req)
+}
+
+// ConvertStreamは複数の生HTTPヘッダをストリームで変換します。
+func (s *Server) ConvertStream(stream pb.HeaderConverter_ConvertStreamServer) error {
+	for {
+		req, e := stream.Recv()
+		if e == io.EOF {
+			return nil
+		}
+		if e != nil {
+			return fmt.Errorf("cannot receive request: %w", e)
+		}
+		resp, e := s.convert(req)
+		if e != nil {
+			return e
+		}
+		if e := stream.Send(resp); e != nil {
+			return fmt.Errorf("cannot send response: %w", e)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright (c) 2022 RFull Development
+// This source code is managed under the MIT license. See LICENSE in the project root.
+package conv
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// structuredHeaderParsersは構造化モード有効時にヘッダ名(小文字)毎に適用するパーサです。
+var structuredHeaderParsers = map[string]func(value string) interface{}{
+	"set-cookie":          parseCookieValue,
+	"cookie":              parseCookieValue,
+	"cache-control":       parseDirectiveList,
+	"pragma":              parseDirectiveList,
+	"content-disposition": parseDirectiveList,
+	"accept":              parseQualityList,
+	"accept-encoding":     parseQualityList,
+	"accept-language":     parseQualityList,
+	"vary":                parseCommaList,
+	"allow":               parseCommaList,
+	"via":                 parseCommaList,
+	"link":                parseLinkList,
+}
+
+// structuredValueは構造化モードが有効な場合に、既知のヘッダ値を入れ子のmap/sliceへ展開します。
+// 未知のヘッダ、あるいは構造化モードが無効な場合は元のvalueをそのまま返却します。
+func (conv *HttpHeaderConverter) structuredValue(key string, value string) interface{} {
+	if !conv.structuredValues {
+		return value
+	}
+	parser, ok := structuredHeaderParsers[strings.ToLower(key)]
+	if !ok {
+		return value
+	}
+	return parser(value)
+}
+
+// parseCookieValueはSet-Cookie/Cookieの値を{name, value, attributes}へ分解します。
+func parseCookieValue(value string) interface{} {
+	parts := strings.Split(value, ";")
+	nameValue := strings.SplitN(strings.TrimSpace(parts[0]), "=", 2)
+	result := map[string]interface{}{
+		"name":  strings.TrimSpace(nameValue[0]),
+		"value": "",
+	}
+	if len(nameValue) == 2 {
+		result["value"] = nameValue[1]
+	}
+	attributes := make(map[string]interface{})
+	for _, p := range parts[1:] {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		kv := strings.SplitN(p, "=", 2)
+		name := strings.ToLower(strings.TrimSpace(kv[0]))
+		switch name {
+		case "path":
+			attributes["path"] = attrValue(kv)
+		case "domain":
+			attributes["domain"] = attrValue(kv)
+		case "expires":
+			attributes["expires"] = attrValue(kv)
+		case "max-age":
+			attributes["maxAge"] = attrValue(kv)
+		case "samesite":
+			attributes["sameSite"] = attrValue(kv)
+		case "secure":
+			attributes["secure"] = true
+		case "httponly":
+			attributes["httpOnly"] = true
+		}
+	}
+	result["attributes"] = attributes
+	return result
+}
+
+// attrValueはCookie属性の"name=value"表現からvalue部分を取り出します。
+func attrValue(kv []string) string {
+	if len(kv) == 2 {
+		return strings.TrimSpace(kv[1])
+	}
+	return ""
+}
+
+// parseDirectiveListはCache-Control/Pragma/Content-Dispositionのような
+// カンマ区切りの"directive"または"directive=value"の並びを{directive: value|true}へ分解します。
+func parseDirectiveList(value string) interface{} {
+	result := make(map[string]interface{})
+	for _, d := range strings.Split(value, ",") {
+		d = strings.TrimSpace(d)
+		if d == "" {
+			continue
+		}
+		kv := strings.SplitN(d, "=", 2)
+		name := strings.TrimSpace(kv[0])
+		if len(kv) == 2 {
+			result[name] = strings.Trim(strings.TrimSpace(kv[1]), `"`)
+		} else {
+			result[name] = true
+		}
+	}
+	return result
+}
+
+// parseQualityListはAccept系ヘッダの"value;q=0.8;param=x"の並びをq値降順の配列へ分解します。
+func parseQualityList(value string) interface{} {
+	items := strings.Split(value, ",")
+	result := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		parts := strings.Split(item, ";")
+		q := 1.0
+		params := make(map[string]interface{})
+		for _, p := range parts[1:] {
+			kv := strings.SplitN(strings.TrimSpace(p), "=", 2)
+			if len(kv) != 2 {
+				continue
+			}
+			name := strings.TrimSpace(kv[0])
+			v := strings.TrimSpace(kv[1])
+			if name == "q" {
+				if parsed, e := strconv.ParseFloat(v, 64); e == nil {
+					q = parsed
+				}
+				continue
+			}
+			params[name] = v
+		}
+		result = append(result, map[string]interface{}{
+			"value":  strings.TrimSpace(parts[0]),
+			"q":      q,
+			"params": params,
+		})
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		qi := result[i].(map[string]interface{})["q"].(float64)
+		qj := result[j].(map[string]interface{})["q"].(float64)
+		return qi > qj
+	})
+	return result
+}
+
+// parseCommaListはVary/Allow/Viaのような単純なカンマ区切りヘッダを文字列配列へ分解します。
+func parseCommaList(value string) interface{} {
+	items := strings.Split(value, ",")
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		result = append(result, item)
+	}
+	return result
+}
+
+// parseLinkListはLinkヘッダの"<uri>; rel=\"next\"; ..."の並びを{uri, rel, ...}の配列へ分解します。
+func parseLinkList(value string) interface{} {
+	links := splitLinkValues(value)
+	result := make([]interface{}, 0, len(links))
+	for _, link := range links {
+		parts := strings.Split(link, ";")
+		uri := strings.Trim(strings.TrimSpace(parts[0]), "<>")
+		entry := map[string]interface{}{"uri": uri}
+		for _, p := range parts[1:] {
+			kv := strings.SplitN(strings.TrimSpace(p), "=", 2)
+			if len(kv) != 2 {
+				continue
+			}
+			name := strings.TrimSpace(kv[0])
+			entry[name] = strings.Trim(strings.TrimSpace(kv[1]), `"`)
+		}
+		result = append(result, entry)
+	}
+	return result
+}
+
+// splitLinkValuesはLinkヘッダの値を、引用符内のカンマを無視してlink-value単位に分割します。
+func splitLinkValues(value string) []string {
+	var result []string
+	inQuotes := false
+	last := 0
+	for i, c := range value {
+		switch c {
+		case '"':
+			inQuotes = !inQuotes
+		case ',':
+			if !inQuotes {
+				result = append(result, strings.TrimSpace(value[last:i]))
+				last = i + 1
+			}
+		}
+	}
+	result = append(result, strings.TrimSpace(value[last:]))
+	return result
+}